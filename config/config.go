@@ -0,0 +1,12 @@
+package config
+
+// Config describes how to construct a libp2p Host. Its fields are
+// populated by the Option functions in the top-level libp2p package and
+// consumed while constructing the Host's Swarm.
+type Config struct {
+	// TransportConfig controls the relative priority of, and allows
+	// disabling, individual transports, security transports, and stream
+	// muxers, instead of relying on the order their Option functions were
+	// passed in.
+	TransportConfig TransportConfig
+}
@@ -0,0 +1,109 @@
+package config
+
+import "sort"
+
+// TransportConfig lets users express explicit ordering and enablement for
+// the transports, security transports, and stream muxers a Host will use,
+// instead of relying on the order options happened to be passed in. It's
+// set via the libp2p.TransportConfig Option.
+type TransportConfig struct {
+	Transports []PrioritizedTransport
+	Security   []PrioritizedTransport
+	Muxers     []PrioritizedTransport
+}
+
+// PrioritizedTransport names one transport/security/muxer entry and its
+// relative priority. Lower Priority values are preferred first. Entries
+// with Enabled false are dropped entirely, letting users turn off e.g. QUIC
+// without recompiling.
+type PrioritizedTransport struct {
+	Name     string
+	Priority int
+	Enabled  bool
+}
+
+func sortedEnabledNames(entries []PrioritizedTransport) []string {
+	enabled := make([]PrioritizedTransport, 0, len(entries))
+	for _, e := range entries {
+		if e.Enabled {
+			enabled = append(enabled, e)
+		}
+	}
+	sort.SliceStable(enabled, func(i, j int) bool { return enabled[i].Priority < enabled[j].Priority })
+	names := make([]string, len(enabled))
+	for i, e := range enabled {
+		names[i] = e.Name
+	}
+	return names
+}
+
+// TransportNames returns the enabled transport names in priority order.
+func (tc TransportConfig) TransportNames() []string { return sortedEnabledNames(tc.Transports) }
+
+// SecurityNames returns the enabled security transport names in priority order.
+func (tc TransportConfig) SecurityNames() []string { return sortedEnabledNames(tc.Security) }
+
+// MuxerNames returns the enabled stream muxer names in priority order.
+func (tc TransportConfig) MuxerNames() []string { return sortedEnabledNames(tc.Muxers) }
+
+// IsTransportEnabled reports false only if name is listed in tc.Transports
+// with Enabled false. Names not listed, and every name when tc.Transports is
+// empty (the zero value disables nothing), are enabled.
+func (tc TransportConfig) IsTransportEnabled(name string) bool {
+	if len(tc.Transports) == 0 {
+		return true
+	}
+	for _, e := range tc.Transports {
+		if e.Name == name {
+			return e.Enabled
+		}
+	}
+	return true
+}
+
+// OrderSecurityProtocols reorders available (security protocol IDs offered
+// during the multistream handshake) by the priority in tc.Security, so the
+// upgrader offers the user's preferred security transport first. Protocols
+// not mentioned in tc.Security keep their relative order and sort after
+// every explicitly prioritized one. Protocols listed with Enabled false are
+// left out.
+func (tc TransportConfig) OrderSecurityProtocols(available []string) []string {
+	return orderByPriority(tc.Security, available)
+}
+
+// OrderMuxerProtocols reorders available (stream muxer protocol IDs offered
+// during the multistream handshake) by the priority in tc.Muxers, the same
+// way OrderSecurityProtocols does for security protocols.
+func (tc TransportConfig) OrderMuxerProtocols(available []string) []string {
+	return orderByPriority(tc.Muxers, available)
+}
+
+func orderByPriority(entries []PrioritizedTransport, available []string) []string {
+	priority := sortedEnabledNames(entries)
+	disabled := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if !e.Enabled {
+			disabled[e.Name] = true
+		}
+	}
+	rank := make(map[string]int, len(priority))
+	for i, name := range priority {
+		rank[name] = i
+	}
+	unranked := len(priority)
+	rankOf := func(name string) int {
+		if r, ok := rank[name]; ok {
+			return r
+		}
+		return unranked
+	}
+
+	out := make([]string, 0, len(available))
+	for _, name := range available {
+		if !disabled[name] {
+			out = append(out, name)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return rankOf(out[i]) < rankOf(out[j]) })
+	return out
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTransportConfigOrdering(t *testing.T) {
+	tc := TransportConfig{
+		Transports: []PrioritizedTransport{
+			{Name: "tcp", Priority: 1, Enabled: true},
+			{Name: "quic", Priority: 0, Enabled: true},
+			{Name: "ws", Priority: 2, Enabled: false},
+		},
+	}
+
+	if got, want := tc.TransportNames(), []string{"quic", "tcp"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("TransportNames() = %v, want %v", got, want)
+	}
+	if tc.IsTransportEnabled("tcp") != true {
+		t.Fatal("expected tcp to be enabled")
+	}
+	if tc.IsTransportEnabled("ws") != false {
+		t.Fatal("expected ws to be disabled")
+	}
+	if tc.IsTransportEnabled("unknown") != true {
+		t.Fatal("expected an unmentioned transport to default to enabled")
+	}
+}
+
+func TestTransportConfigEmptyEnablesEverything(t *testing.T) {
+	var tc TransportConfig
+	if !tc.IsTransportEnabled("tcp") {
+		t.Fatal("zero-value TransportConfig should disable nothing")
+	}
+}
+
+func TestOrderSecurityProtocols(t *testing.T) {
+	tc := TransportConfig{
+		Security: []PrioritizedTransport{
+			{Name: "noise", Priority: 0, Enabled: true},
+			{Name: "tls", Priority: 1, Enabled: true},
+		},
+	}
+	available := []string{"tls", "noise", "plaintext"}
+	got := tc.OrderSecurityProtocols(available)
+	want := []string{"noise", "tls", "plaintext"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("OrderSecurityProtocols() = %v, want %v", got, want)
+	}
+}
+
+func TestOrderMuxerProtocolsNoPriority(t *testing.T) {
+	var tc TransportConfig
+	available := []string{"yamux", "mplex"}
+	got := tc.OrderMuxerProtocols(available)
+	if !reflect.DeepEqual(got, available) {
+		t.Fatalf("OrderMuxerProtocols() with no priority = %v, want unchanged %v", got, available)
+	}
+}
@@ -0,0 +1,17 @@
+// Package libp2p constructs libp2p Hosts from Option functions; see New.
+package libp2p
+
+import (
+	"github.com/libp2p/go-libp2p/config"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm"
+)
+
+// New constructs the Swarm that backs a libp2p Host from opts, including
+// honoring any transport/security/muxer priorities set via TransportConfig.
+func New(opts ...Option) (*swarm.Swarm, error) {
+	var cfg config.Config
+	if err := cfg.Apply(opts...); err != nil {
+		return nil, err
+	}
+	return swarm.NewSwarm(swarm.ApplyTransportConfig(cfg))
+}
@@ -0,0 +1,25 @@
+package libp2p
+
+import "github.com/libp2p/go-libp2p/config"
+
+// Option is a libp2p config option that can be given to the libp2p
+// constructor (`libp2p.New`).
+type Option = config.Option
+
+// TransportConfig lets you control the relative priority of, and disable,
+// individual transports, security transports, and stream muxers -- e.g. to
+// prefer QUIC over TCP, prefer yamux over mplex when dialing over TCP, or
+// turn QUIC off entirely without recompiling:
+//
+//	libp2p.New(libp2p.TransportConfig(config.TransportConfig{
+//		Transports: []config.PrioritizedTransport{
+//			{Name: "quic", Priority: 0, Enabled: true},
+//			{Name: "tcp", Priority: 1, Enabled: true},
+//		},
+//	}))
+func TransportConfig(tc config.TransportConfig) Option {
+	return func(cfg *config.Config) error {
+		cfg.TransportConfig = tc
+		return nil
+	}
+}
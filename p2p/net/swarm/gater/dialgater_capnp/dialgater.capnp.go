@@ -0,0 +1,110 @@
+// Package dialgater_capnp is the Go binding for dialgater.capnp, hand
+// maintained to match the schema (capnpc-go isn't run as part of this
+// module's build). Keep the two in sync: a struct layout or pointer-index
+// change in dialgater.capnp must be mirrored here.
+package dialgater_capnp
+
+import (
+	"context"
+
+	capnp "capnproto.org/go/capnp/v3"
+)
+
+type Decision uint16
+
+const (
+	Decision_allow        Decision = 0
+	Decision_deny         Decision = 1
+	Decision_deprioritize Decision = 2
+)
+
+func (c Decision) String() string {
+	switch c {
+	case Decision_allow:
+		return "allow"
+	case Decision_deny:
+		return "deny"
+	case Decision_deprioritize:
+		return "deprioritize"
+	default:
+		return "unknown"
+	}
+}
+
+type Direction uint16
+
+const (
+	Direction_inbound  Direction = 0
+	Direction_outbound Direction = 1
+)
+
+// DialRequest corresponds to the DialRequest struct in dialgater.capnp.
+type DialRequest struct{ capnp.Struct }
+
+const DialRequest_TypeID = 0x9eb32e19f9718001
+
+// DialRequest has three pointer-typed fields (peerId, multiaddr, transport)
+// plus the data-word direction field -- keep PointerCount in sync with
+// dialgater.capnp if a field is added.
+func NewDialRequest(s *capnp.Segment) (DialRequest, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return DialRequest{st}, err
+}
+
+func NewRootDialRequest(s *capnp.Segment) (DialRequest, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return DialRequest{st}, err
+}
+
+func ReadRootDialRequest(msg *capnp.Message) (DialRequest, error) {
+	root, err := msg.Root()
+	return DialRequest{root.Struct()}, err
+}
+
+func (s DialRequest) PeerId() ([]byte, error) { return s.Struct.Ptr(0).Data() }
+func (s DialRequest) SetPeerId(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s DialRequest) Multiaddr() (string, error) { return s.Struct.Ptr(1).TextDefault("") }
+func (s DialRequest) SetMultiaddr(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s DialRequest) Direction() Direction { return Direction(s.Struct.Uint16(0)) }
+func (s DialRequest) SetDirection(v Direction) {
+	s.Struct.SetUint16(0, uint16(v))
+}
+
+func (s DialRequest) Transport() (string, error) { return s.Struct.Ptr(2).TextDefault("") }
+func (s DialRequest) SetTransport(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// DialGater_shouldDial_Results corresponds to the shouldDial method's
+// result struct.
+type DialGater_shouldDial_Results struct{ capnp.Struct }
+
+func (s DialGater_shouldDial_Results) Decision() Decision { return Decision(s.Struct.Uint16(0)) }
+func (s DialGater_shouldDial_Results) SetDecision(v Decision) {
+	s.Struct.SetUint16(0, uint16(v))
+}
+
+// DialGater is a client for the DialGater interface.
+type DialGater struct{ Client capnp.Client }
+
+// ShouldDial sends the shouldDial RPC and waits for the supervisor's
+// response.
+func (c DialGater) ShouldDial(ctx context.Context, req DialRequest) (DialGater_shouldDial_Results, error) {
+	answer, release := c.Client.SendCall(ctx, capnp.Send{
+		Method:      capnp.Method{InterfaceID: DialGater_TypeID, MethodID: 0},
+		PlaceArgs:   func(s capnp.Struct) error { return s.SetPtr(0, req.Struct.ToPtr()) },
+		ArgsSize:    capnp.ObjectSize{PointerCount: 1},
+		ResultsSize: capnp.ObjectSize{DataSize: 8},
+	})
+	defer release()
+	results, err := answer.Struct()
+	return DialGater_shouldDial_Results{results}, err
+}
+
+const DialGater_TypeID = 0x9eb32e19f9718002
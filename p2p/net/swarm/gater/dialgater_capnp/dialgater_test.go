@@ -0,0 +1,43 @@
+package dialgater_capnp
+
+import (
+	"bytes"
+	"testing"
+
+	capnp "capnproto.org/go/capnp/v3"
+)
+
+func TestDialRequestRoundTrip(t *testing.T) {
+	_, seg := capnp.NewSingleSegmentMessage(nil)
+	req, err := NewRootDialRequest(seg)
+	if err != nil {
+		t.Fatalf("NewRootDialRequest: %v", err)
+	}
+
+	if err := req.SetPeerId([]byte("peer-id")); err != nil {
+		t.Fatalf("SetPeerId: %v", err)
+	}
+	if err := req.SetMultiaddr("/ip4/127.0.0.1/tcp/4001"); err != nil {
+		t.Fatalf("SetMultiaddr: %v", err)
+	}
+	if err := req.SetTransport("tcp"); err != nil {
+		t.Fatalf("SetTransport: %v", err)
+	}
+	req.SetDirection(Direction_outbound)
+
+	peerID, err := req.PeerId()
+	if err != nil || !bytes.Equal(peerID, []byte("peer-id")) {
+		t.Fatalf("PeerId() = %q, %v, want %q, nil", peerID, err, "peer-id")
+	}
+	if addr, err := req.Multiaddr(); err != nil || addr != "/ip4/127.0.0.1/tcp/4001" {
+		t.Fatalf("Multiaddr() = %q, %v", addr, err)
+	}
+	// Transport must round-trip independently of Multiaddr: they're
+	// distinct pointer slots (a prior layout bug aliased them).
+	if tr, err := req.Transport(); err != nil || tr != "tcp" {
+		t.Fatalf("Transport() = %q, %v, want %q, nil", tr, err, "tcp")
+	}
+	if req.Direction() != Direction_outbound {
+		t.Fatalf("Direction() = %v, want %v", req.Direction(), Direction_outbound)
+	}
+}
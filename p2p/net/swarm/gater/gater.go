@@ -0,0 +1,199 @@
+// Package gater lets a Swarm defer dial admission decisions to an
+// out-of-process supervisor, reachable over a Cap'n Proto RPC connection.
+// This is the extension point behind swarm.WithDialGater: a node operator
+// can run rate-limiting, geo-blocking, or reputation policy in a separate
+// process, in a different language, without recompiling libp2p.
+package gater
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	dialgatercapnp "github.com/libp2p/go-libp2p/p2p/net/swarm/gater/dialgater_capnp"
+
+	capnp "capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/rpc"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// Decision is the admission decision for a single dial attempt.
+type Decision int
+
+const (
+	Allow Decision = iota
+	Deny
+	Deprioritize
+)
+
+// DialGater is consulted by the Swarm before dialing a peer. It's the Go
+// side of the extension point; RPCDialGater is the implementation that
+// forwards the decision to an out-of-process supervisor.
+type DialGater interface {
+	ShouldDial(p peer.ID, addr ma.Multiaddr, dir network.Direction, transport string) Decision
+}
+
+// cacheEntry holds a cached decision and the time it expires.
+type cacheEntry struct {
+	decision Decision
+	expires  time.Time
+}
+
+// RPCDialGater forwards dial admission decisions to an out-of-process
+// supervisor over a Cap'n Proto RPC connection, typically a unix socket.
+// Decisions are cached for TTL to keep the hot dial path off the wire.
+//
+// If the supervisor is unreachable, ShouldDial returns the decision set by
+// WithFallbackDecision (Allow by default) and
+// increments the swarm/gater_bypass_total counter, so an operator can alert
+// on a supervisor outage instead of silently losing policy enforcement.
+type RPCDialGater struct {
+	network            string
+	address            string
+	ttl                time.Duration
+	dialTimeout        time.Duration
+	fallbackDecision   Decision
+	onFallback         func()
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+
+	connMu sync.Mutex
+	conn   *rpc.Conn
+	client dialgatercapnp.DialGater
+}
+
+// NewRPCDialGater returns a DialGater that connects to a supervisor
+// listening on a unix socket at socketPath. See the Option functions in
+// this package for TTL, timeout, and fallback behavior.
+func NewRPCDialGater(socketPath string, opts ...Option) *RPCDialGater {
+	g := &RPCDialGater{
+		network:          "unix",
+		address:          socketPath,
+		ttl:              30 * time.Second,
+		dialTimeout:      2 * time.Second,
+		fallbackDecision: Allow,
+		cache:            make(map[string]cacheEntry),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+func (g *RPCDialGater) cacheKey(p peer.ID, addr ma.Multiaddr, dir network.Direction, transport string) string {
+	return p.String() + "|" + addr.String() + "|" + transport + "|" + getDirection(dir)
+}
+
+func getDirection(dir network.Direction) string {
+	if dir == network.DirOutbound {
+		return "outbound"
+	}
+	return "inbound"
+}
+
+// ShouldDial implements DialGater.
+func (g *RPCDialGater) ShouldDial(p peer.ID, addr ma.Multiaddr, dir network.Direction, transport string) Decision {
+	key := g.cacheKey(p, addr, dir, transport)
+
+	g.mu.Lock()
+	if entry, ok := g.cache[key]; ok && time.Now().Before(entry.expires) {
+		g.mu.Unlock()
+		return entry.decision
+	}
+	g.mu.Unlock()
+
+	decision, err := g.askSupervisor(p, addr, dir, transport)
+	if err != nil {
+		recordGaterBypass()
+		if g.onFallback != nil {
+			g.onFallback()
+		}
+		return g.fallbackDecision
+	}
+
+	g.mu.Lock()
+	g.cache[key] = cacheEntry{decision: decision, expires: time.Now().Add(g.ttl)}
+	g.mu.Unlock()
+	return decision
+}
+
+// askSupervisor makes the actual Cap'n Proto RPC call. The underlying
+// capnp rpc.Conn frames and size-limits every message it reads, so a
+// malformed or adversarial supervisor can desync or oversize a message but
+// can't crash the host; a failed call just falls through to the
+// fallback decision.
+func (g *RPCDialGater) askSupervisor(p peer.ID, addr ma.Multiaddr, dir network.Direction, transport string) (Decision, error) {
+	client, err := g.bootstrapClient()
+	if err != nil {
+		return 0, err
+	}
+
+	msg, seg := capnp.NewSingleSegmentMessage(nil)
+	req, err := dialgatercapnp.NewRootDialRequest(seg)
+	if err != nil {
+		return 0, err
+	}
+	if err := req.SetPeerId([]byte(p)); err != nil {
+		return 0, err
+	}
+	if err := req.SetMultiaddr(addr.String()); err != nil {
+		return 0, err
+	}
+	if dir == network.DirOutbound {
+		req.SetDirection(dialgatercapnp.Direction_outbound)
+	} else {
+		req.SetDirection(dialgatercapnp.Direction_inbound)
+	}
+	if err := req.SetTransport(transport); err != nil {
+		return 0, err
+	}
+	_ = msg // the request struct above is populated in msg's segment
+
+	ctx, cancel := context.WithTimeout(context.Background(), g.dialTimeout)
+	defer cancel()
+
+	results, err := client.ShouldDial(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	switch results.Decision() {
+	case dialgatercapnp.Decision_deny:
+		return Deny, nil
+	case dialgatercapnp.Decision_deprioritize:
+		return Deprioritize, nil
+	default:
+		return Allow, nil
+	}
+}
+
+func (g *RPCDialGater) bootstrapClient() (dialgatercapnp.DialGater, error) {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+
+	if g.conn != nil && !g.conn.Closed() {
+		return g.client, nil
+	}
+
+	conn, err := net.DialTimeout(g.network, g.address, g.dialTimeout)
+	if err != nil {
+		return dialgatercapnp.DialGater{}, err
+	}
+	transport := rpc.NewStreamTransport(conn)
+	g.conn = rpc.NewConn(transport, nil)
+	g.client = dialgatercapnp.DialGater{Client: g.conn.Bootstrap(context.Background())}
+	return g.client, nil
+}
+
+// Close tears down the RPC connection to the supervisor, if one is open.
+func (g *RPCDialGater) Close() error {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	if g.conn == nil {
+		return nil
+	}
+	return g.conn.Close()
+}
@@ -0,0 +1,23 @@
+package gater
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+)
+
+// gaterBypass counts every ShouldDial call that fell back to the default
+// decision because the supervisor was unreachable, so an operator can
+// alert on a policy supervisor outage instead of silently losing
+// enforcement.
+var gaterBypass = stats.Int64("swarm/gater_bypass_total", "Dial gater RPC calls that fell back to the default decision", stats.UnitDimensionless)
+
+var GaterBypassView = &view.View{
+	Measure:     gaterBypass,
+	Aggregation: view.Sum(),
+}
+
+func recordGaterBypass() {
+	stats.Record(context.Background(), gaterBypass.M(1))
+}
@@ -0,0 +1,33 @@
+package gater
+
+import "time"
+
+// Option configures an RPCDialGater.
+type Option func(*RPCDialGater)
+
+// WithCacheTTL sets how long a supervisor's decision is cached for a given
+// (peer, address, direction, transport) tuple before it's asked again.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(g *RPCDialGater) { g.ttl = ttl }
+}
+
+// WithDialTimeout bounds how long a single RPC call to the supervisor, or
+// the initial connection to it, is allowed to take before falling back.
+func WithDialTimeout(d time.Duration) Option {
+	return func(g *RPCDialGater) { g.dialTimeout = d }
+}
+
+// WithFallbackDecision sets the decision ShouldDial returns when the
+// supervisor is unreachable or returns an error. Defaults to Allow, so a
+// down supervisor degrades to unrestricted dialing rather than stalling
+// the node; pass Deny for a fail-closed policy instead.
+func WithFallbackDecision(d Decision) Option {
+	return func(g *RPCDialGater) { g.fallbackDecision = d }
+}
+
+// WithFallbackHook registers a callback invoked every time ShouldDial falls
+// back because the supervisor couldn't be reached, in addition to the
+// swarm/gater_bypass_total counter. Useful for logging or alerting.
+func WithFallbackHook(f func()) Option {
+	return func(g *RPCDialGater) { g.onFallback = f }
+}
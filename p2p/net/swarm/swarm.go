@@ -0,0 +1,81 @@
+package swarm
+
+import (
+	"sync"
+
+	"github.com/libp2p/go-libp2p/config"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/core/transport"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm/gater"
+)
+
+// Swarm is the libp2p Host's low-level connection manager: dialing, muxing,
+// and the metrics/policy hooks configured by this package's Option
+// functions all hang off of it. Each Swarm keeps its own copy of that
+// configuration so that a process hosting multiple Hosts -- a relay
+// fronting several identities, or a test binary constructing many Swarms --
+// doesn't have one Swarm's options leak into another's.
+type Swarm struct {
+	openCensusEnabled bool
+	promReporter      *prometheusMetricsReporter
+
+	// transportPriorities is installed via WithTransportPriorities. A nil
+	// value means "no preference": DialPeer tries addrs in the order they
+	// were given, and security protocols and muxers keep the order they
+	// were registered in.
+	transportPriorities *config.TransportConfig
+	transports          map[string]transport.Transport
+	securityProtocols   []string
+	muxerProtocols      []string
+
+	// protocolCardinalityLimit bounds the number of distinct protocol IDs
+	// the bytes_sent/bytes_received views track as their own tag value;
+	// see WithProtocolMetricsCardinalityLimit.
+	protocolCardinalityLimit int
+	protocolCardinality      struct {
+		sync.Mutex
+		seen map[protocol.ID]struct{}
+	}
+
+	// dialGater is consulted by DialPeer before dialing, if set via
+	// WithDialGater. nil means every dial is allowed.
+	dialGater gater.DialGater
+}
+
+// NewSwarm constructs a Swarm, applying opts in order and returning the
+// first error any of them produce.
+func NewSwarm(opts ...Option) (*Swarm, error) {
+	s := &Swarm{
+		openCensusEnabled:        true,
+		protocolCardinalityLimit: defaultProtocolCardinalityLimit,
+	}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// AddTransport registers t to dial and listen on addrs whose transport name
+// (as used in config.TransportConfig) is name.
+func (s *Swarm) AddTransport(name string, t transport.Transport) {
+	if s.transports == nil {
+		s.transports = make(map[string]transport.Transport)
+	}
+	s.transports[name] = t
+}
+
+// AddSecurityProtocol registers protoID as available for the multistream
+// security handshake performed when upgrading a connection dialed over a
+// transport that doesn't provide its own security, such as TCP.
+func (s *Swarm) AddSecurityProtocol(protoID string) {
+	s.securityProtocols = append(s.securityProtocols, protoID)
+}
+
+// AddMuxerProtocol registers protoID as available for the multistream
+// stream-muxer handshake performed when upgrading a connection dialed over
+// a transport that doesn't provide its own multiplexing, such as TCP.
+func (s *Swarm) AddMuxerProtocol(protoID string) {
+	s.muxerProtocols = append(s.muxerProtocols, protoID)
+}
@@ -0,0 +1,40 @@
+package swarm
+
+import (
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm/gater"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// WithDialGater installs a gater.DialGater that this Swarm consults before
+// dialing any address, letting an operator defer admission decisions (rate
+// limits, geo-blocking, reputation) to an out-of-process supervisor. See
+// the gater package for the RPC-backed implementation.
+func WithDialGater(g gater.DialGater) Option {
+	return func(s *Swarm) error {
+		s.dialGater = g
+		return nil
+	}
+}
+
+// checkDialGater is called from DialPeer for each address about to be
+// dialed. allowed is false if the installed gater denied the dial.
+// deprioritized reports a Deprioritize decision; DialPeer honors it by
+// holding the address back and dialing it only after every
+// non-deprioritized address has failed, rather than by anything this
+// function does itself.
+func (s *Swarm) checkDialGater(p peer.ID, addr ma.Multiaddr, dir network.Direction, transport string) (allowed bool, deprioritized bool) {
+	if s.dialGater == nil {
+		return true, false
+	}
+	switch s.dialGater.ShouldDial(p, addr, dir, transport) {
+	case gater.Deny:
+		return false, false
+	case gater.Deprioritize:
+		return true, true
+	default:
+		return true, false
+	}
+}
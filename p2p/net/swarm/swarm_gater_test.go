@@ -0,0 +1,47 @@
+package swarm
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm/gater"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+type fakeGater gater.Decision
+
+func (g fakeGater) ShouldDial(peer.ID, ma.Multiaddr, network.Direction, string) gater.Decision {
+	return gater.Decision(g)
+}
+
+func TestCheckDialGaterNoneInstalled(t *testing.T) {
+	s := &Swarm{}
+	addr, _ := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/4001")
+	allowed, deprioritized := s.checkDialGater("", addr, network.DirOutbound, "tcp")
+	if !allowed || deprioritized {
+		t.Fatalf("with no gater installed, expected (true, false), got (%v, %v)", allowed, deprioritized)
+	}
+}
+
+func TestCheckDialGaterDecisions(t *testing.T) {
+	addr, _ := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/4001")
+
+	cases := []struct {
+		decision          gater.Decision
+		wantAllowed       bool
+		wantDeprioritized bool
+	}{
+		{gater.Allow, true, false},
+		{gater.Deny, false, false},
+		{gater.Deprioritize, true, true},
+	}
+	for _, c := range cases {
+		s := &Swarm{dialGater: fakeGater(c.decision)}
+		allowed, deprioritized := s.checkDialGater("", addr, network.DirOutbound, "tcp")
+		if allowed != c.wantAllowed || deprioritized != c.wantDeprioritized {
+			t.Fatalf("decision %v: got (%v, %v), want (%v, %v)", c.decision, allowed, deprioritized, c.wantAllowed, c.wantDeprioritized)
+		}
+	}
+}
@@ -63,7 +63,10 @@ var (
 	}
 )
 
-var DefaultViews = []*view.View{connOpenView, connClosedView, connDurationView, connHandshakeLatencyView}
+var DefaultViews = []*view.View{
+	connOpenView, connClosedView, connDurationView, connHandshakeLatencyView,
+	bytesSentView, bytesReceivedView,
+}
 
 func getDirection(dir network.Direction) string {
 	switch dir {
@@ -94,29 +97,49 @@ func appendConnectionState(tags []tag.Mutator, cs network.ConnectionState) []tag
 	return tags
 }
 
-func recordConnectionOpened(dir network.Direction, cs network.ConnectionState) {
-	tags := make([]tag.Mutator, 0, 4)
-	tags = append(tags, tag.Upsert(directionTag, getDirection(dir)))
-	tags = appendConnectionState(tags, cs)
-	stats.RecordWithTags(context.Background(), tags, connsOpened.M(1))
+func (s *Swarm) recordConnectionOpened(dir network.Direction, cs network.ConnectionState) {
+	if s.openCensusEnabled {
+		tags := make([]tag.Mutator, 0, 4)
+		tags = append(tags, tag.Upsert(directionTag, getDirection(dir)))
+		tags = appendConnectionState(tags, cs)
+		stats.RecordWithTags(context.Background(), tags, connsOpened.M(1))
+	}
+	if s.promReporter != nil {
+		s.promReporter.ConnectionOpened(dir, cs)
+	}
 }
 
-func recordConnectionClosed(dir network.Direction, cs network.ConnectionState) {
-	tags := make([]tag.Mutator, 0, 4)
-	tags = append(tags, tag.Upsert(directionTag, getDirection(dir)))
-	tags = appendConnectionState(tags, cs)
-	stats.RecordWithTags(context.Background(), tags, connsClosed.M(1))
+func (s *Swarm) recordConnectionClosed(dir network.Direction, cs network.ConnectionState) {
+	if s.openCensusEnabled {
+		tags := make([]tag.Mutator, 0, 4)
+		tags = append(tags, tag.Upsert(directionTag, getDirection(dir)))
+		tags = appendConnectionState(tags, cs)
+		stats.RecordWithTags(context.Background(), tags, connsClosed.M(1))
+	}
+	if s.promReporter != nil {
+		s.promReporter.ConnectionClosed(dir, cs)
+	}
 }
 
-func recordConnectionDuration(dir network.Direction, t time.Duration, cs network.ConnectionState) {
-	tags := make([]tag.Mutator, 0, 4)
-	tags = append(tags, tag.Upsert(directionTag, getDirection(dir)))
-	tags = appendConnectionState(tags, cs)
-	stats.RecordWithTags(context.Background(), tags, connDuration.M(t.Milliseconds()))
+func (s *Swarm) recordConnectionDuration(dir network.Direction, t time.Duration, cs network.ConnectionState) {
+	if s.openCensusEnabled {
+		tags := make([]tag.Mutator, 0, 4)
+		tags = append(tags, tag.Upsert(directionTag, getDirection(dir)))
+		tags = appendConnectionState(tags, cs)
+		stats.RecordWithTags(context.Background(), tags, connDuration.M(t.Milliseconds()))
+	}
+	if s.promReporter != nil {
+		s.promReporter.ConnectionDuration(dir, t, cs)
+	}
 }
 
-func recordHandshakeLatency(t time.Duration, cs network.ConnectionState) {
-	tags := make([]tag.Mutator, 0, 3)
-	tags = appendConnectionState(tags, cs)
-	stats.RecordWithTags(context.Background(), tags, connHandshakeLatency.M(t.Milliseconds()))
+func (s *Swarm) recordHandshakeLatency(t time.Duration, cs network.ConnectionState) {
+	if s.openCensusEnabled {
+		tags := make([]tag.Mutator, 0, 3)
+		tags = appendConnectionState(tags, cs)
+		stats.RecordWithTags(context.Background(), tags, connHandshakeLatency.M(t.Milliseconds()))
+	}
+	if s.promReporter != nil {
+		s.promReporter.HandshakeLatency(t, cs)
+	}
 }
@@ -0,0 +1,165 @@
+package swarm
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/core/transport"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	bytesSent     = stats.Int64(metricNamespace+"bytes_sent", "Bytes Sent", stats.UnitBytes)
+	bytesReceived = stats.Int64(metricNamespace+"bytes_received", "Bytes Received", stats.UnitBytes)
+)
+
+var protocolTag, _ = tag.NewKey("protocol")
+
+var (
+	bytesSentView = &view.View{
+		Measure:     bytesSent,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{directionTag, transportTag, protocolTag},
+	}
+	bytesReceivedView = &view.View{
+		Measure:     bytesReceived,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{directionTag, transportTag, protocolTag},
+	}
+)
+
+const otherProtocol = "other"
+
+// defaultProtocolCardinalityLimit bounds the number of distinct protocol
+// IDs recorded as their own tag value. Protocols seen after the limit is
+// reached are folded into "other", so a swarm talking hundreds of
+// short-lived protocols doesn't blow up exporter cardinality. 0 disables
+// per-protocol tagging entirely: every stream is recorded as "other".
+// Overridden per-Swarm via WithProtocolMetricsCardinalityLimit.
+const defaultProtocolCardinalityLimit = 100
+
+// WithProtocolMetricsCardinalityLimit caps the number of distinct protocol
+// IDs this Swarm tracks in the bytes_sent/bytes_received views, folding the
+// rest into "other". Pass 0 to disable per-protocol tagging entirely.
+func WithProtocolMetricsCardinalityLimit(n int) Option {
+	return func(s *Swarm) error {
+		s.protocolCardinalityLimit = n
+		return nil
+	}
+}
+
+// boundedProtocolID returns proto, unless this Swarm's cardinality limit
+// has already been reached by other protocols, in which case it returns
+// "other".
+func (s *Swarm) boundedProtocolID(proto protocol.ID) string {
+	if s.protocolCardinalityLimit <= 0 {
+		return otherProtocol
+	}
+	s.protocolCardinality.Lock()
+	defer s.protocolCardinality.Unlock()
+	if s.protocolCardinality.seen == nil {
+		s.protocolCardinality.seen = make(map[protocol.ID]struct{})
+	}
+	if _, ok := s.protocolCardinality.seen[proto]; ok {
+		return string(proto)
+	}
+	if len(s.protocolCardinality.seen) >= s.protocolCardinalityLimit {
+		return otherProtocol
+	}
+	s.protocolCardinality.seen[proto] = struct{}{}
+	return string(proto)
+}
+
+// tagMutatorPool reuses the []tag.Mutator slices built on every Read/Write
+// of an instrumented stream, so recording bytes doesn't allocate a new
+// slice each time.
+var tagMutatorPool = sync.Pool{
+	New: func() interface{} {
+		s := make([]tag.Mutator, 0, 3)
+		return &s
+	},
+}
+
+func (s *Swarm) recordBytes(measure *stats.Int64Measure, dir network.Direction, transport string, proto protocol.ID, n int64) {
+	if n <= 0 {
+		return
+	}
+	tagsPtr := tagMutatorPool.Get().(*[]tag.Mutator)
+	tags := (*tagsPtr)[:0]
+	tags = append(tags,
+		tag.Upsert(directionTag, getDirection(dir)),
+		tag.Upsert(transportTag, transport),
+		tag.Upsert(protocolTag, s.boundedProtocolID(proto)),
+	)
+	stats.RecordWithTags(context.Background(), tags, measure.M(n))
+	*tagsPtr = tags
+	tagMutatorPool.Put(tagsPtr)
+}
+
+// instrumentedStream wraps a network.MuxedStream to record bytes_sent and
+// bytes_received, tagged by direction, transport, and the negotiated
+// protocol ID. The swarm installs this wrapper around every muxed stream
+// it opens or accepts; see Swarm.NewStream and Swarm.acceptStream.
+type instrumentedStream struct {
+	network.MuxedStream
+
+	swarm     *Swarm
+	dir       network.Direction
+	transport string
+	proto     protocol.ID
+}
+
+// wrapStreamWithMetrics returns ms wrapped so that all reads and writes are
+// recorded through s's bytes_sent/bytes_received views.
+func (s *Swarm) wrapStreamWithMetrics(ms network.MuxedStream, dir network.Direction, cs network.ConnectionState, proto protocol.ID) network.MuxedStream {
+	transport := cs.Transport
+	if transport == "" {
+		transport = "unknown"
+	}
+	return &instrumentedStream{MuxedStream: ms, swarm: s, dir: dir, transport: transport, proto: proto}
+}
+
+func (s *instrumentedStream) Read(p []byte) (int, error) {
+	n, err := s.MuxedStream.Read(p)
+	if n > 0 {
+		s.swarm.recordBytes(bytesReceived, s.dir, s.transport, s.proto, int64(n))
+	}
+	return n, err
+}
+
+func (s *instrumentedStream) Write(p []byte) (int, error) {
+	n, err := s.MuxedStream.Write(p)
+	if n > 0 {
+		s.swarm.recordBytes(bytesSent, s.dir, s.transport, s.proto, int64(n))
+	}
+	return n, err
+}
+
+var _ io.ReadWriter = (*instrumentedStream)(nil)
+
+// NewStream opens a new stream for protoID over conn, returning it wrapped
+// for byte-counter metrics.
+func (s *Swarm) NewStream(ctx context.Context, conn transport.CapableConn, protoID protocol.ID) (network.MuxedStream, error) {
+	ms, err := conn.OpenStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.wrapStreamWithMetrics(ms, network.DirOutbound, conn.ConnState(), protoID), nil
+}
+
+// acceptStream accepts the next incoming stream on conn, returning it
+// wrapped for byte-counter metrics. protoID is the protocol negotiated for
+// the stream by the caller's multistream handling.
+func (s *Swarm) acceptStream(conn transport.CapableConn, protoID protocol.ID) (network.MuxedStream, error) {
+	ms, err := conn.AcceptStream()
+	if err != nil {
+		return nil, err
+	}
+	return s.wrapStreamWithMetrics(ms, network.DirInbound, conn.ConnState(), protoID), nil
+}
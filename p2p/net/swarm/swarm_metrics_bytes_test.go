@@ -0,0 +1,33 @@
+package swarm
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+func TestBoundedProtocolIDRespectsLimit(t *testing.T) {
+	s := &Swarm{protocolCardinalityLimit: 2}
+
+	if got, want := s.boundedProtocolID("/a/1.0.0"), "/a/1.0.0"; got != want {
+		t.Fatalf("first protocol: got %q, want %q", got, want)
+	}
+	if got, want := s.boundedProtocolID("/b/1.0.0"), "/b/1.0.0"; got != want {
+		t.Fatalf("second protocol: got %q, want %q", got, want)
+	}
+	// Limit reached: a third, previously-unseen protocol folds into "other" ...
+	if got, want := s.boundedProtocolID("/c/1.0.0"), otherProtocol; got != want {
+		t.Fatalf("third protocol: got %q, want %q", got, want)
+	}
+	// ... but protocols already counted keep their own tag value.
+	if got, want := s.boundedProtocolID("/a/1.0.0"), "/a/1.0.0"; got != want {
+		t.Fatalf("repeat of first protocol: got %q, want %q", got, want)
+	}
+}
+
+func TestBoundedProtocolIDZeroLimitDisablesTagging(t *testing.T) {
+	s := &Swarm{protocolCardinalityLimit: 0}
+	if got := s.boundedProtocolID(protocol.ID("/a/1.0.0")); got != otherProtocol {
+		t.Fatalf("got %q, want %q", got, otherProtocol)
+	}
+}
@@ -0,0 +1,114 @@
+package swarm
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// prometheusMetricsReporter records the same connection lifecycle events as
+// the OpenCensus views in swarm_metrics.go, but through a Prometheus registry supplied by
+// the user. It's installed by WithMetricsRegisterer.
+type prometheusMetricsReporter struct {
+	connsOpened      *prometheus.CounterVec
+	connsClosed      *prometheus.CounterVec
+	connDuration     *prometheus.HistogramVec
+	handshakeLatency *prometheus.HistogramVec
+}
+
+func newPrometheusMetricsReporter(reg prometheus.Registerer) (*prometheusMetricsReporter, error) {
+	r := &prometheusMetricsReporter{
+		connsOpened: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "libp2p_swarm_connections_opened_total",
+			Help: "Connections Opened",
+		}, []string{"dir", "transport", "security", "muxer"}),
+		connsClosed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "libp2p_swarm_connections_closed_total",
+			Help: "Connections Closed",
+		}, []string{"dir", "transport", "security", "muxer"}),
+		connDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "libp2p_swarm_connection_duration_seconds",
+			Help:    "Duration of a Connection",
+			Buckets: prometheus.ExponentialBuckets(0.25, 2, 20),
+		}, []string{"dir", "transport", "security", "muxer"}),
+		handshakeLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "libp2p_swarm_handshake_latency_seconds",
+			Help:    "Duration of the libp2p handshake",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 17),
+		}, []string{"transport", "security", "muxer"}),
+	}
+	for _, c := range []prometheus.Collector{r.connsOpened, r.connsClosed, r.connDuration, r.handshakeLatency} {
+		if err := reg.Register(c); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+				continue
+			}
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func connectionStateLabels(dir string, cs network.ConnectionState) prometheus.Labels {
+	transport := cs.Transport
+	if transport == "" {
+		transport = "unknown"
+	}
+	labels := prometheus.Labels{
+		"transport": transport,
+		"security":  cs.Security,
+		"muxer":     cs.StreamMultiplexer,
+	}
+	if dir != "" {
+		labels["dir"] = dir
+	}
+	return labels
+}
+
+func (r *prometheusMetricsReporter) ConnectionOpened(dir network.Direction, cs network.ConnectionState) {
+	r.connsOpened.With(connectionStateLabels(getDirection(dir), cs)).Inc()
+}
+
+func (r *prometheusMetricsReporter) ConnectionClosed(dir network.Direction, cs network.ConnectionState) {
+	r.connsClosed.With(connectionStateLabels(getDirection(dir), cs)).Inc()
+}
+
+func (r *prometheusMetricsReporter) ConnectionDuration(dir network.Direction, t time.Duration, cs network.ConnectionState) {
+	r.connDuration.With(connectionStateLabels(getDirection(dir), cs)).Observe(t.Seconds())
+}
+
+func (r *prometheusMetricsReporter) HandshakeLatency(t time.Duration, cs network.ConnectionState) {
+	labels := connectionStateLabels("", cs)
+	delete(labels, "dir")
+	r.handshakeLatency.With(labels).Observe(t.Seconds())
+}
+
+// Option is a Swarm constructor option.
+type Option func(*Swarm) error
+
+// WithMetricsRegisterer installs a Prometheus-native metrics reporter that
+// records the same connection lifecycle events as the default OpenCensus
+// views, registering its collectors against reg. OpenCensus recording stays
+// on unless WithOpenCensusDisabled is also passed, so pass both to avoid
+// double-recording.
+func WithMetricsRegisterer(reg prometheus.Registerer) Option {
+	return func(s *Swarm) error {
+		r, err := newPrometheusMetricsReporter(reg)
+		if err != nil {
+			return err
+		}
+		s.promReporter = r
+		return nil
+	}
+}
+
+// WithOpenCensusDisabled turns off OpenCensus recording of connection
+// metrics on this Swarm. Use this together with WithMetricsRegisterer once
+// all consumers have moved to the Prometheus reporter.
+func WithOpenCensusDisabled() Option {
+	return func(s *Swarm) error {
+		s.openCensusEnabled = false
+		return nil
+	}
+}
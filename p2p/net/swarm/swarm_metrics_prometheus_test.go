@@ -0,0 +1,34 @@
+package swarm
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestWithMetricsRegistererPropagatesRegistrationErrors(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	conflicting := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "libp2p_swarm_connections_opened_total",
+		Help: "a different metric that happens to collide on name",
+	}, []string{"dir"})
+	if err := reg.Register(conflicting); err != nil {
+		t.Fatalf("registering the conflicting collector: %v", err)
+	}
+
+	if err := WithMetricsRegisterer(reg)(&Swarm{}); err == nil {
+		t.Fatal("expected an error from a descriptor conflict, got nil")
+	} else if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		t.Fatalf("expected a genuine conflict error, got AlreadyRegisteredError: %v", err)
+	}
+}
+
+func TestWithMetricsRegistererToleratesSameRegistryReuse(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if err := WithMetricsRegisterer(reg)(&Swarm{}); err != nil {
+		t.Fatalf("first registration: %v", err)
+	}
+	if err := WithMetricsRegisterer(reg)(&Swarm{}); err != nil {
+		t.Fatalf("second registration against the same registry should be tolerated via AlreadyRegisteredError: %v", err)
+	}
+}
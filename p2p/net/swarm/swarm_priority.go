@@ -0,0 +1,197 @@
+package swarm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/libp2p/go-libp2p/config"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/transport"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// WithTransportPriorities installs an explicit dial-time ordering for
+// transports, security protocols, and stream muxers on this Swarm, letting
+// a user prefer e.g. QUIC over TCP, or disable a transport outright,
+// without recompiling. See config.TransportConfig. The libp2p constructor
+// installs this automatically from config.Config.TransportConfig; see
+// ApplyTransportConfig.
+func WithTransportPriorities(tc config.TransportConfig) Option {
+	return func(s *Swarm) error {
+		s.transportPriorities = &tc
+		return nil
+	}
+}
+
+// ApplyTransportConfig turns the TransportConfig set via the top-level
+// libp2p.TransportConfig Option into the swarm.Option that installs it,
+// so the libp2p constructor can thread cfg.TransportConfig through to the
+// Swarm it builds.
+func ApplyTransportConfig(cfg config.Config) Option {
+	return WithTransportPriorities(cfg.TransportConfig)
+}
+
+// transportNameForAddr returns the name (as used in config.TransportConfig)
+// of the transport that would dial addr.
+func transportNameForAddr(addr ma.Multiaddr) string {
+	protos := addr.Protocols()
+	for i := len(protos) - 1; i >= 0; i-- {
+		switch protos[i].Code {
+		case ma.P_QUIC, ma.P_QUIC_V1:
+			return "quic"
+		case ma.P_WEBTRANSPORT:
+			return "webtransport"
+		case ma.P_WSS:
+			return "wss"
+		case ma.P_WS:
+			return "ws"
+		case ma.P_TCP:
+			return "tcp"
+		}
+	}
+	return "unknown"
+}
+
+// sortAddrsByPriority reorders addrs according to the transport priorities
+// installed via WithTransportPriorities, dropping any whose transport was
+// explicitly disabled. Addrs whose transport wasn't mentioned in the
+// configuration keep their relative order and sort after every explicitly
+// prioritized transport. With no TransportConfig installed, addrs is
+// returned unchanged.
+func (s *Swarm) sortAddrsByPriority(addrs []ma.Multiaddr) []ma.Multiaddr {
+	if s.transportPriorities == nil {
+		return addrs
+	}
+	rank := make(map[string]int, len(s.transportPriorities.Transports))
+	for i, name := range s.transportPriorities.TransportNames() {
+		rank[name] = i
+	}
+	unranked := len(rank)
+
+	out := make([]ma.Multiaddr, 0, len(addrs))
+	for _, addr := range addrs {
+		if s.transportPriorities.IsTransportEnabled(transportNameForAddr(addr)) {
+			out = append(out, addr)
+		}
+	}
+	rankOf := func(addr ma.Multiaddr) int {
+		if r, ok := rank[transportNameForAddr(addr)]; ok {
+			return r
+		}
+		return unranked
+	}
+	sort.SliceStable(out, func(i, j int) bool { return rankOf(out[i]) < rankOf(out[j]) })
+	return out
+}
+
+// NewUpgrader decides the order in which availableSecurity and
+// availableMuxers are offered during the multistream handshake used to
+// upgrade a connection dialed over a transport that isn't already secure
+// and muxed (i.e. anything but QUIC), honoring the priorities installed
+// via WithTransportPriorities.
+func (s *Swarm) NewUpgrader(availableSecurity, availableMuxers []string) *Upgrader {
+	security, muxer := availableSecurity, availableMuxers
+	if s.transportPriorities != nil {
+		security = s.transportPriorities.OrderSecurityProtocols(availableSecurity)
+		muxer = s.transportPriorities.OrderMuxerProtocols(availableMuxers)
+	}
+	return &Upgrader{securityProtocols: security, muxerProtocols: muxer}
+}
+
+// Upgrader holds the security and stream muxer protocol orders decided by
+// NewUpgrader for a connection over a transport that doesn't provide its
+// own. It performs no handshake itself; dialAddr uses the first protocol of
+// each list as the connection's metrics label where the transport reported
+// none.
+type Upgrader struct {
+	securityProtocols []string
+	muxerProtocols    []string
+}
+
+func first(protos []string) string {
+	if len(protos) == 0 {
+		return ""
+	}
+	return protos[0]
+}
+
+// dialAddr dials addr and records the resulting connection's metrics,
+// filling in a priority-derived security/muxer label only where the
+// transport didn't already report one. See DialPeer.
+func (s *Swarm) dialAddr(ctx context.Context, p peer.ID, addr ma.Multiaddr) (transport.CapableConn, error) {
+	name := transportNameForAddr(addr)
+	tpt, ok := s.transports[name]
+	if !ok {
+		return nil, fmt.Errorf("no transport registered for %s", addr)
+	}
+
+	conn, err := tpt.Dial(ctx, addr, p)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := conn.ConnState()
+	if name != "quic" && name != "quic-v1" {
+		// Only fill in a priority-derived label where the transport
+		// didn't already report one -- e.g. because there's no real
+		// handshake/negotiation step behind Upgrader yet. A value
+		// conn.ConnState() already set came from the transport itself
+		// and must win.
+		upgrader := s.NewUpgrader(s.securityProtocols, s.muxerProtocols)
+		if cs.Security == "" {
+			cs.Security = first(upgrader.securityProtocols)
+		}
+		if cs.StreamMultiplexer == "" {
+			cs.StreamMultiplexer = first(upgrader.muxerProtocols)
+		}
+	}
+	s.recordConnectionOpened(network.DirOutbound, cs)
+	return conn, nil
+}
+
+// DialPeer attempts to establish a connection to p, trying addrs in the
+// order installed via WithTransportPriorities, skipping any whose transport
+// was explicitly disabled or whose dial gater denies it. Addrs the dial
+// gater deprioritized are held back and tried only after every other addr
+// has failed.
+func (s *Swarm) DialPeer(ctx context.Context, p peer.ID, addrs []ma.Multiaddr) (transport.CapableConn, error) {
+	ordered := s.sortAddrsByPriority(addrs)
+
+	var deprioritized []ma.Multiaddr
+	lastErr := fmt.Errorf("no addresses to dial for peer %s", p)
+
+	for _, addr := range ordered {
+		name := transportNameForAddr(addr)
+
+		allowed, deprioritize := s.checkDialGater(p, addr, network.DirOutbound, name)
+		if !allowed {
+			lastErr = fmt.Errorf("dial to %s denied by dial gater", addr)
+			continue
+		}
+		if deprioritize {
+			deprioritized = append(deprioritized, addr)
+			continue
+		}
+
+		conn, err := s.dialAddr(ctx, p, addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return conn, nil
+	}
+
+	for _, addr := range deprioritized {
+		conn, err := s.dialAddr(ctx, p, addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return conn, nil
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,53 @@
+package swarm
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/config"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func mustAddr(t *testing.T, s string) ma.Multiaddr {
+	t.Helper()
+	a, err := ma.NewMultiaddr(s)
+	if err != nil {
+		t.Fatalf("NewMultiaddr(%q): %v", s, err)
+	}
+	return a
+}
+
+func TestSortAddrsByPriority(t *testing.T) {
+	tcp := mustAddr(t, "/ip4/127.0.0.1/tcp/4001")
+	quic := mustAddr(t, "/ip4/127.0.0.1/udp/4001/quic-v1")
+	ws := mustAddr(t, "/ip4/127.0.0.1/tcp/4002/ws")
+
+	s := &Swarm{
+		transportPriorities: &config.TransportConfig{
+			Transports: []config.PrioritizedTransport{
+				{Name: "quic", Priority: 0, Enabled: true},
+				{Name: "tcp", Priority: 1, Enabled: true},
+				{Name: "ws", Priority: 2, Enabled: false},
+			},
+		},
+	}
+
+	got := s.sortAddrsByPriority([]ma.Multiaddr{tcp, ws, quic})
+	if len(got) != 2 {
+		t.Fatalf("expected ws to be dropped, got %v", got)
+	}
+	if !got[0].Equal(quic) || !got[1].Equal(tcp) {
+		t.Fatalf("expected [quic, tcp] in priority order, got %v", got)
+	}
+}
+
+func TestSortAddrsByPriorityNoConfig(t *testing.T) {
+	s := &Swarm{}
+	tcp := mustAddr(t, "/ip4/127.0.0.1/tcp/4001")
+	quic := mustAddr(t, "/ip4/127.0.0.1/udp/4001/quic-v1")
+
+	got := s.sortAddrsByPriority([]ma.Multiaddr{tcp, quic})
+	if !got[0].Equal(tcp) || !got[1].Equal(quic) {
+		t.Fatalf("expected addrs unchanged with no TransportConfig, got %v", got)
+	}
+}
@@ -0,0 +1,59 @@
+package libp2pquic
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// connMetrics surfaces per-connection QUIC path state through the registry
+// configured via WithMetrics / WithMetricsRegisterer, mirroring the
+// connsOpened/connsClosed counters the swarm already records for every
+// transport.
+type connMetrics struct {
+	mtu              *prometheus.GaugeVec
+	congestionWindow *prometheus.GaugeVec
+}
+
+func newConnMetrics(reg prometheus.Registerer) (*connMetrics, error) {
+	m := &connMetrics{
+		mtu: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "libp2p_quic_path_mtu_bytes",
+			Help: "Current path MTU of a QUIC connection",
+		}, []string{"dir"}),
+		congestionWindow: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "libp2p_quic_congestion_window_bytes",
+			Help: "Current congestion window of a QUIC connection",
+		}, []string{"dir"}),
+	}
+	for _, g := range []**prometheus.GaugeVec{&m.mtu, &m.congestionWindow} {
+		if err := reg.Register(*g); err != nil {
+			are, ok := err.(prometheus.AlreadyRegisteredError)
+			if !ok {
+				return nil, err
+			}
+			existing, ok := are.ExistingCollector.(*prometheus.GaugeVec)
+			if !ok {
+				return nil, err
+			}
+			// Record into the gauges already in reg, so they keep surfacing.
+			*g = existing
+		}
+	}
+	return m, nil
+}
+
+// recordMTU is called from a connection's logging.ConnectionTracer
+// whenever quic-go reports an updated path MTU; see Transport.tracerFor.
+func (m *connMetrics) recordMTU(dir string, mtu uint64) {
+	if m == nil {
+		return
+	}
+	m.mtu.WithLabelValues(dir).Set(float64(mtu))
+}
+
+// recordCongestionWindow is called from a connection's
+// logging.ConnectionTracer whenever quic-go reports updated congestion
+// metrics; see Transport.tracerFor.
+func (m *connMetrics) recordCongestionWindow(dir string, congestionWindow uint64) {
+	if m == nil {
+		return
+	}
+	m.congestionWindow.WithLabelValues(dir).Set(float64(congestionWindow))
+}
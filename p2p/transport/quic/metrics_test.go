@@ -0,0 +1,34 @@
+package libp2pquic
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestNewTransportPropagatesMetricsRegistrationErrors(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	conflicting := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "libp2p_quic_path_mtu_bytes",
+		Help: "a different metric that happens to collide on name",
+	}, []string{"dir", "extra"})
+	if err := reg.Register(conflicting); err != nil {
+		t.Fatalf("registering the conflicting collector: %v", err)
+	}
+
+	if _, err := NewTransport(WithMetricsRegisterer(reg)); err == nil {
+		t.Fatal("expected NewTransport to surface the descriptor conflict, got nil error")
+	} else if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		t.Fatalf("expected a genuine conflict error, got AlreadyRegisteredError: %v", err)
+	}
+}
+
+func TestNewTransportToleratesSameRegistryReuse(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := NewTransport(WithMetricsRegisterer(reg)); err != nil {
+		t.Fatalf("first transport: %v", err)
+	}
+	if _, err := NewTransport(WithMetricsRegisterer(reg)); err != nil {
+		t.Fatalf("second transport sharing the same registry should be tolerated via AlreadyRegisteredError: %v", err)
+	}
+}
@@ -1,11 +1,30 @@
 package libp2pquic
 
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/quic-go/quic-go"
+)
+
 type Option func(opts *config) error
 
+// defaultMaxConnectionReceiveWindow raises quic-go's own 15 MB default: a
+// libp2p connection multiplexes many streams over one QUIC connection, so
+// nodes on high-BDP links routinely hit the throughput ceiling the default
+// imposes.
+const defaultMaxConnectionReceiveWindow = 30 * 1024 * 1024
+
 type config struct {
 	disableReuseport bool
 	disableDraft29   bool
 	metrics          bool
+	registerer       prometheus.Registerer
+
+	disablePathMTUDiscovery bool
+
+	initialStreamReceiveWindow     uint64
+	maxStreamReceiveWindow         uint64
+	initialConnectionReceiveWindow uint64
+	maxConnectionReceiveWindow     uint64
 }
 
 func (cfg *config) apply(opts ...Option) error {
@@ -14,10 +33,27 @@ func (cfg *config) apply(opts ...Option) error {
 			return err
 		}
 	}
+	if cfg.maxConnectionReceiveWindow == 0 {
+		cfg.maxConnectionReceiveWindow = defaultMaxConnectionReceiveWindow
+	}
 
 	return nil
 }
 
+// quicConfig translates the flow-control and path-discovery options onto a
+// quic-go Config, used when constructing the transport's listener/dialer.
+// Zero fields are left for quic-go to default, except
+// MaxConnectionReceiveWindow, which apply already raised above.
+func (cfg *config) quicConfig() *quic.Config {
+	return &quic.Config{
+		InitialStreamReceiveWindow:     cfg.initialStreamReceiveWindow,
+		MaxStreamReceiveWindow:         cfg.maxStreamReceiveWindow,
+		InitialConnectionReceiveWindow: cfg.initialConnectionReceiveWindow,
+		MaxConnectionReceiveWindow:     cfg.maxConnectionReceiveWindow,
+		DisablePathMTUDiscovery:        cfg.disablePathMTUDiscovery,
+	}
+}
+
 func DisableReuseport() Option {
 	return func(cfg *config) error {
 		cfg.disableReuseport = true
@@ -32,10 +68,72 @@ func DisableDraft29() Option {
 	}
 }
 
-// WithMetrics enables Prometheus metrics collection.
+// WithMetrics enables Prometheus metrics collection using the default
+// registerer. Use WithMetricsRegisterer to collect into a registry of your
+// own instead.
 func WithMetrics() Option {
 	return func(cfg *config) error {
 		cfg.metrics = true
 		return nil
 	}
 }
+
+// WithMetricsRegisterer enables Prometheus metrics collection, registering
+// the transport's MTU and congestion-window gauges against reg instead of
+// the default registerer. Passing the same reg used with swarm.WithMetricsRegisterer
+// lets both layers report into one registry.
+func WithMetricsRegisterer(reg prometheus.Registerer) Option {
+	return func(cfg *config) error {
+		cfg.metrics = true
+		cfg.registerer = reg
+		return nil
+	}
+}
+
+// WithInitialStreamReceiveWindow sets the stream-level flow-control window
+// offered at stream creation, in bytes.
+func WithInitialStreamReceiveWindow(size uint64) Option {
+	return func(cfg *config) error {
+		cfg.initialStreamReceiveWindow = size
+		return nil
+	}
+}
+
+// WithMaxStreamReceiveWindow sets the maximum the stream-level flow-control
+// window is allowed to grow to, in bytes.
+func WithMaxStreamReceiveWindow(size uint64) Option {
+	return func(cfg *config) error {
+		cfg.maxStreamReceiveWindow = size
+		return nil
+	}
+}
+
+// WithInitialConnectionReceiveWindow sets the connection-level flow-control
+// window offered at connection establishment, in bytes.
+func WithInitialConnectionReceiveWindow(size uint64) Option {
+	return func(cfg *config) error {
+		cfg.initialConnectionReceiveWindow = size
+		return nil
+	}
+}
+
+// WithMaxConnectionReceiveWindow sets the maximum the connection-level
+// flow-control window is allowed to grow to, in bytes. A libp2p connection
+// multiplexes many streams, so this is set higher than quic-go's own
+// default; see defaultMaxConnectionReceiveWindow.
+func WithMaxConnectionReceiveWindow(size uint64) Option {
+	return func(cfg *config) error {
+		cfg.maxConnectionReceiveWindow = size
+		return nil
+	}
+}
+
+// WithDisablePathMTUDiscovery disables Path MTU Discovery (DPLPMTUD). This
+// is mainly useful for network diagnostics on links where MTU probing
+// itself is causing problems.
+func WithDisablePathMTUDiscovery() Option {
+	return func(cfg *config) error {
+		cfg.disablePathMTUDiscovery = true
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+package libp2pquic
+
+import "testing"
+
+func TestDefaultMaxConnectionReceiveWindow(t *testing.T) {
+	var cfg config
+	if err := cfg.apply(); err != nil {
+		t.Fatalf("apply() with no options: %v", err)
+	}
+	if cfg.maxConnectionReceiveWindow != defaultMaxConnectionReceiveWindow {
+		t.Fatalf("maxConnectionReceiveWindow = %d, want default %d", cfg.maxConnectionReceiveWindow, defaultMaxConnectionReceiveWindow)
+	}
+	qCfg := cfg.quicConfig()
+	if qCfg.MaxConnectionReceiveWindow != defaultMaxConnectionReceiveWindow {
+		t.Fatalf("quicConfig().MaxConnectionReceiveWindow = %d, want %d", qCfg.MaxConnectionReceiveWindow, defaultMaxConnectionReceiveWindow)
+	}
+}
+
+func TestExplicitFlowControlWindowsOverrideDefault(t *testing.T) {
+	var cfg config
+	if err := cfg.apply(
+		WithInitialStreamReceiveWindow(1),
+		WithMaxStreamReceiveWindow(2),
+		WithInitialConnectionReceiveWindow(3),
+		WithMaxConnectionReceiveWindow(4),
+		WithDisablePathMTUDiscovery(),
+	); err != nil {
+		t.Fatalf("apply(): %v", err)
+	}
+	qCfg := cfg.quicConfig()
+	if qCfg.InitialStreamReceiveWindow != 1 || qCfg.MaxStreamReceiveWindow != 2 ||
+		qCfg.InitialConnectionReceiveWindow != 3 || qCfg.MaxConnectionReceiveWindow != 4 {
+		t.Fatalf("quicConfig() = %+v, want explicit windows to pass through unchanged", qCfg)
+	}
+	if !qCfg.DisablePathMTUDiscovery {
+		t.Fatal("expected DisablePathMTUDiscovery to be set")
+	}
+}
@@ -0,0 +1,84 @@
+package libp2pquic
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/logging"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Transport is the QUIC transport. It owns the config assembled from this
+// package's Option functions and applies it -- flow-control windows, path
+// MTU discovery, and metrics -- to every connection it dials or accepts.
+type Transport struct {
+	cfg     config
+	metrics *connMetrics
+}
+
+// NewTransport applies opts and returns the resulting Transport.
+func NewTransport(opts ...Option) (*Transport, error) {
+	var cfg config
+	if err := cfg.apply(opts...); err != nil {
+		return nil, err
+	}
+	t := &Transport{cfg: cfg}
+	if cfg.metrics {
+		reg := cfg.registerer
+		if reg == nil {
+			reg = prometheus.DefaultRegisterer
+		}
+		m, err := newConnMetrics(reg)
+		if err != nil {
+			return nil, err
+		}
+		t.metrics = m
+	}
+	return t, nil
+}
+
+// dial opens a QUIC connection to raddr using the transport's configured
+// flow-control and path-MTU-discovery settings, and reports the
+// connection's MTU and congestion window through the transport's gauges
+// for as long as it's open.
+func (t *Transport) dial(ctx context.Context, raddr string, tlsConf *tls.Config) (quic.Connection, error) {
+	return quic.DialAddr(ctx, raddr, tlsConf, t.quicConfigFor("outbound"))
+}
+
+// listen starts accepting QUIC connections on pconn using the transport's
+// configured flow-control and path-MTU-discovery settings, reporting each
+// accepted connection's MTU and congestion window through the transport's
+// gauges for as long as it's open.
+func (t *Transport) listen(pconn net.PacketConn, tlsConf *tls.Config) (*quic.Listener, error) {
+	return quic.Listen(pconn, tlsConf, t.quicConfigFor("inbound"))
+}
+
+// quicConfigFor returns the transport's quic.Config with a ConnectionTracer
+// attached that forwards path MTU and congestion-window updates to the
+// transport's metrics, if metrics are enabled.
+func (t *Transport) quicConfigFor(dir string) *quic.Config {
+	qCfg := t.cfg.quicConfig()
+	if t.metrics != nil {
+		qCfg.Tracer = t.tracerFor(dir)
+	}
+	return qCfg
+}
+
+// tracerFor returns a quic-go ConnectionTracer constructor that records
+// every path MTU and congestion-window update quic-go reports for a
+// connection into the transport's gauges, labeled by dir.
+func (t *Transport) tracerFor(dir string) func(context.Context, logging.Perspective, logging.ConnectionID) *logging.ConnectionTracer {
+	return func(context.Context, logging.Perspective, logging.ConnectionID) *logging.ConnectionTracer {
+		return &logging.ConnectionTracer{
+			UpdatedMTU: func(mtu logging.ByteCount, _ bool) {
+				t.metrics.recordMTU(dir, uint64(mtu))
+			},
+			UpdatedMetrics: func(_ *logging.RTTStats, cwnd, _ logging.ByteCount, _ int) {
+				t.metrics.recordCongestionWindow(dir, uint64(cwnd))
+			},
+		}
+	}
+}